@@ -0,0 +1,232 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peap
+
+// LinkerK is the interface that objects must implement to be added to
+// and/or removed from HeapK objects. It is the d-ary analog of Linker: Left
+// and Right become Child(0) and Child(1).
+type LinkerK[T any] interface {
+	Child(i int) T
+	SetChild(i int, elem T)
+}
+
+// ElementK is the item that is used at the HeapK API level.
+type ElementK[T any] interface {
+	comparable
+	LinkerK[T]
+	Less(T) bool
+}
+
+// HeapK implements a pointer-based min-heap with k children per node. A
+// larger k makes for a shallower tree (fewer pointer chases per Push/Pop) at
+// the cost of an O(k) scan for the smallest child on each level of Pop.
+// HeapK{2} is the same shape as Heap, modulo the Child-based Linker.
+type HeapK[T ElementK[T]] struct {
+	k    int
+	size int
+	root T
+}
+
+// NewHeapK returns an empty HeapK with k children per node. k must be at
+// least 2.
+func NewHeapK[T ElementK[T]](k int) *HeapK[T] {
+	if k < 2 {
+		panic("peap: NewHeapK requires k >= 2")
+	}
+	return &HeapK[T]{k: k}
+}
+
+// Peek returns the next Element to be removed from the HeapK.
+func (h *HeapK[T]) Peek() T {
+	return h.root
+}
+
+// Len returns the number of Elements currently in the HeapK.
+func (h *HeapK[T]) Len() int {
+	return h.size
+}
+
+// attachChildK installs child as parent's i-th child.
+func attachChildK[T ElementK[T]](parent T, i int, child T) {
+	parent.SetChild(i, child)
+}
+
+// pathToK returns the sequence of child indices from the root to the
+// 1-indexed level-order position pos of a complete k-ary tree. It is the
+// k-ary generalization of the binary digits peap.Heap derives from
+// log2(h.size); unlike the binary case there's no single-word bit trick for
+// general k, so the path is just computed and stored.
+func pathToK(pos, k int) []int {
+	var path []int
+	for pos > 1 {
+		pos -= 2
+		path = append(path, pos%k)
+		pos = pos/k + 1
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// Push adds an Element to the HeapK.
+func (h *HeapK[T]) Push(e T) {
+	h.size++
+	h.root = h.insert(h.root, pathToK(h.size, h.k), 0, e)
+}
+
+func (h *HeapK[T]) insert(cur T, path []int, idx int, new T) T {
+	if idx == len(path) {
+		// Install the new leaf. Pop doesn't clear the children of the
+		// Element it removes (see HeapK.Pop), so a reused, previously
+		// popped new could still carry stale child pointers; clear only
+		// the slots that aren't already zero so a fresh Element (the
+		// common case) never grows its children slice.
+		var zero T
+		for i := 0; i < h.k; i++ {
+			if new.Child(i) != zero {
+				new.SetChild(i, zero)
+			}
+		}
+		return new
+	}
+
+	c := path[idx]
+	attachChildK(cur, c, h.insert(cur.Child(c), path, idx+1, new))
+	if cur.Child(c).Less(cur) {
+		return h.swapWithChild(cur, c)
+	}
+	return cur
+}
+
+// swapWithChild promotes cur's c-th child above cur, demoting cur to take
+// the child's old slot. It costs O(k), since every other child of the two
+// nodes has to be relinked.
+func (h *HeapK[T]) swapWithChild(cur T, c int) T {
+	oldRoot := cur
+	newRoot := cur.Child(c)
+
+	oldChildren := make([]T, h.k)
+	for i := 0; i < h.k; i++ {
+		oldChildren[i] = oldRoot.Child(i)
+	}
+
+	// oldRoot takes over every one of newRoot's old children, including
+	// the slot newRoot itself used to occupy (oldRoot.Child(c)), just
+	// like swapWithLeft/swapWithRight do for the binary case.
+	for i := 0; i < h.k; i++ {
+		attachChildK(oldRoot, i, newRoot.Child(i))
+	}
+	for i := 0; i < h.k; i++ {
+		if i == c {
+			continue
+		}
+		attachChildK(newRoot, i, oldChildren[i])
+	}
+	attachChildK(newRoot, c, oldRoot)
+	return newRoot
+}
+
+// Pop removes an Element from the HeapK.
+func (h *HeapK[T]) Pop() T {
+	if h.size == 0 {
+		var zero T
+		return zero
+	}
+
+	removed := h.root
+	last := h.remove(h.root, pathToK(h.size, h.k), 0)
+	h.size--
+
+	var zero T
+	if last != zero {
+		for i := 0; i < h.k; i++ {
+			attachChildK(last, i, removed.Child(i))
+		}
+		h.root = h.fixDown(last)
+	} else {
+		h.root = last
+	}
+
+	return removed
+}
+
+func (h *HeapK[T]) remove(cur T, path []int, idx int) T {
+	var zero T
+	if idx == len(path) {
+		return zero
+	}
+
+	c := path[idx]
+	got := h.remove(cur.Child(c), path, idx+1)
+	if got == zero {
+		got = cur.Child(c)
+		cur.SetChild(c, zero)
+	}
+	return got
+}
+
+// fixDown fixes a heap where only the root is potentially in the wrong
+// place.
+func (h *HeapK[T]) fixDown(cur T) T {
+	var zero T
+	minIdx := -1
+	var minChild T
+	for i := 0; i < h.k; i++ {
+		c := cur.Child(i)
+		if c == zero {
+			continue
+		}
+		if minIdx == -1 || c.Less(minChild) {
+			minIdx, minChild = i, c
+		}
+	}
+
+	if minIdx == -1 || !minChild.Less(cur) {
+		// Nothing to fix.
+		return cur
+	}
+
+	newRoot := h.swapWithChild(cur, minIdx)
+	attachChildK(newRoot, minIdx, h.fixDown(newRoot.Child(minIdx)))
+	return newRoot
+}
+
+// EntryK is a default implementation of LinkerK. Users can embed this type
+// in their structs to make them automatically implement most of the methods
+// needed by HeapK. Unlike Entry, its child slice grows lazily so that it
+// works with any k.
+type EntryK[T ElementK[T]] struct {
+	children []T
+}
+
+// Child returns the i-th child of e, or the zero value if it hasn't been
+// set.
+func (e *EntryK[T]) Child(i int) T {
+	if i >= len(e.children) {
+		var zero T
+		return zero
+	}
+	return e.children[i]
+}
+
+// SetChild assigns elem as the i-th child of e.
+func (e *EntryK[T]) SetChild(i int, elem T) {
+	var zero T
+	for len(e.children) <= i {
+		e.children = append(e.children, zero)
+	}
+	e.children[i] = elem
+}
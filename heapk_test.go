@@ -0,0 +1,96 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package peap
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+type elementK struct {
+	EntryK[*elementK]
+	value uint32
+}
+
+func (e *elementK) Less(elem *elementK) bool {
+	return e.value < elem.value
+}
+
+func TestHeapKInsertAndRemove(t *testing.T) {
+	for _, k := range []int{2, 3, 5, 8} {
+		t.Run(fmt.Sprint("k=", k), func(t *testing.T) {
+			values := make(sort.IntSlice, 0, 100)
+			h := NewHeapK[*elementK](k)
+			for i := 0; i < 100; i++ {
+				cur := rand.Uint32() % 1000
+				values = append(values, int(cur))
+				h.Push(&elementK{value: cur})
+			}
+
+			values.Sort()
+			for len(values) > 0 {
+				got := int(h.Pop().value)
+				if got != values[0] {
+					t.Errorf("got h.Pop() = %d, want = %d", got, values[0])
+				}
+				values = values[1:]
+			}
+
+			if got := h.Len(); got != 0 {
+				t.Errorf("removed all elements, got h.Len() = %d, want = 0", got)
+			}
+			var zero *elementK
+			if h.root != zero {
+				t.Errorf("removed all elements, got h.root = %v, want = nil", h.root)
+			}
+		})
+	}
+}
+
+func TestNewHeapKPanicsOnSmallK(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewHeapK(1) did not panic")
+		}
+	}()
+	NewHeapK[*elementK](1)
+}
+
+func BenchmarkHeapK(b *testing.B) {
+	for _, k := range []int{2, 4, 8} {
+		for _, size := range []int{5, 10, 100} {
+			b.Run(fmt.Sprintf("k=%d/%d", k, size), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					values := make([]*elementK, 0, size)
+					for i := 0; i < cap(values); i++ {
+						values = append(values, &elementK{value: rand.Uint32() % 1000})
+					}
+
+					h := NewHeapK[*elementK](k)
+					b.StartTimer()
+
+					for _, v := range values {
+						h.Push(v)
+					}
+					for h.Pop() != nil {
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package genbench
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/iangudger/peap"
+)
+
+func BenchmarkGenericHeap(b *testing.B) {
+	for _, size := range []int{5, 10, 100} {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				jobs := make([]*Job, 0, size)
+				for i := 0; i < cap(jobs); i++ {
+					jobs = append(jobs, &Job{priority: rand.Uint32() % 1000})
+				}
+
+				var h peap.Heap[*Job]
+				b.StartTimer()
+
+				for _, j := range jobs {
+					h.Push(j)
+				}
+				for h.Pop() != nil {
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSpecializedHeap(b *testing.B) {
+	for _, size := range []int{5, 10, 100} {
+		b.Run(fmt.Sprint(size), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				jobs := make([]*Job, 0, size)
+				for i := 0; i < cap(jobs); i++ {
+					jobs = append(jobs, &Job{priority: rand.Uint32() % 1000})
+				}
+
+				var h JobHeap
+				b.StartTimer()
+
+				for _, j := range jobs {
+					h.Push(j)
+				}
+				for h.Pop() != nil {
+				}
+			}
+		})
+	}
+}
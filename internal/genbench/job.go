@@ -0,0 +1,36 @@
+// Copyright 2018 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package genbench benchmarks a peapgen-specialized heap against
+// peap.Heap[T] to demonstrate the effect of removing the interface
+// dispatch on Push/Pop.
+package genbench
+
+import "github.com/iangudger/peap"
+
+//go:generate go run github.com/iangudger/peap/cmd/peapgen -type=Job -heap=JobHeap -less "a.priority < b.priority" -out job_heap_gen.go
+
+// Job is the element used by both the generic and the generated heap in
+// this package's benchmarks.
+type Job struct {
+	peap.Entry[*Job]
+	JobLinks
+
+	priority uint32
+}
+
+// Less implements peap.Element so that Job can be used with peap.Heap.
+func (j *Job) Less(other *Job) bool {
+	return j.priority < other.priority
+}
@@ -0,0 +1,166 @@
+// Code generated by peapgen from -type=Job; DO NOT EDIT.
+
+package genbench
+
+import "math/bits"
+
+// JobLinks is the intrusive linkage embedded in Job so that it
+// can be stored in a JobHeap. It plays the same role as peap.Entry, but
+// JobHeap reaches into its fields directly instead of going through the
+// peap.Linker interface.
+type JobLinks struct {
+	left, right *Job
+}
+
+// JobHeap is a specialized min-heap for *Job, generated by peapgen.
+type JobHeap struct {
+	size int
+	root *Job
+}
+
+// JobHeapLess reports whether a sorts before b. It is the -less expression
+// peapgen was invoked with.
+func JobHeapLess(a, b *Job) bool {
+	return a.priority < b.priority
+}
+
+// Peek returns the next *Job to be removed from h.
+func (h *JobHeap) Peek() *Job {
+	return h.root
+}
+
+// Len returns the number of elements currently in h.
+func (h *JobHeap) Len() int {
+	return h.size
+}
+
+// Push adds e to h.
+func (h *JobHeap) Push(e *Job) {
+	h.size++
+	h.root = h.insert(h.root, JobHeaplog2(h.size)-1, e)
+}
+
+func JobHeapswapWithLeft(cur *Job) *Job {
+	oldRoot := cur
+	newRoot := cur.left
+
+	orr := oldRoot.right
+	oldRoot.right = newRoot.right
+	newRoot.right = orr
+
+	oldRoot.left = newRoot.left
+	newRoot.left = oldRoot
+	return newRoot
+}
+
+func JobHeapswapWithRight(cur *Job) *Job {
+	oldRoot := cur
+	newRoot := cur.right
+
+	orl := oldRoot.left
+	oldRoot.left = newRoot.left
+	newRoot.left = orl
+
+	oldRoot.right = newRoot.right
+	newRoot.right = oldRoot
+	return newRoot
+}
+
+// insert is Push's recursive step; see peap.Heap.Push for the derivation
+// of the order parameter.
+func (h *JobHeap) insert(cur *Job, order int, new *Job) *Job {
+	if order < 0 {
+		new.left = nil
+		new.right = nil
+		return new
+	}
+
+	val := h.size >> uint(order)
+	if val&1 == 0 {
+		cur.left = h.insert(cur.left, order-1, new)
+		if JobHeapLess(cur.left, cur) {
+			return JobHeapswapWithLeft(cur)
+		}
+		return cur
+	}
+
+	cur.right = h.insert(cur.right, order-1, new)
+	if JobHeapLess(cur.right, cur) {
+		return JobHeapswapWithRight(cur)
+	}
+	return cur
+}
+
+// remove removes the last element from h in level order and returns it.
+func (h *JobHeap) remove(cur *Job, order int) *Job {
+	if order < 0 {
+		return nil
+	}
+
+	val := h.size >> uint(order)
+	if val&1 == 0 {
+		got := h.remove(cur.left, order-1)
+		if got == nil {
+			got = cur.left
+			cur.left = nil
+		}
+		return got
+	}
+
+	got := h.remove(cur.right, order-1)
+	if got == nil {
+		got = cur.right
+		cur.right = nil
+	}
+	return got
+}
+
+func JobHeapfixDown(cur *Job) *Job {
+	if cur.left == nil && cur.right == nil {
+		return cur
+	}
+
+	if cur.right == nil || JobHeapLess(cur.left, cur.right) {
+		if !JobHeapLess(cur.left, cur) {
+			return cur
+		}
+		newRoot := JobHeapswapWithLeft(cur)
+		newRoot.left = JobHeapfixDown(newRoot.left)
+		return newRoot
+	}
+
+	if !JobHeapLess(cur.right, cur) {
+		return cur
+	}
+	newRoot := JobHeapswapWithRight(cur)
+	newRoot.right = JobHeapfixDown(newRoot.right)
+	return newRoot
+}
+
+// Pop removes and returns the minimum element of h.
+func (h *JobHeap) Pop() *Job {
+	if h.size == 0 {
+		return nil
+	}
+
+	removed := h.root
+	last := h.remove(h.root, JobHeaplog2(h.size)-1)
+	h.size--
+
+	if last != nil {
+		last.left = removed.left
+		last.right = removed.right
+		h.root = JobHeapfixDown(last)
+	} else {
+		h.root = nil
+	}
+
+	return removed
+}
+
+// JobHeaplog2 calculates the integer log base 2 of n for positive n. See
+// peap's own log2 for the derivation; it is duplicated here so that the
+// generated file has no dependency on the peap package.
+func JobHeaplog2(n int) int {
+	return 63 - bits.LeadingZeros64(uint64(n))
+}
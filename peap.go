@@ -23,7 +23,9 @@
 package peap
 
 import (
+	"encoding/binary"
 	"fmt"
+	"io"
 	"math/bits"
 )
 
@@ -35,8 +37,10 @@ import (
 type Linker[T any] interface {
 	Left() T
 	Right() T
+	Parent() T
 	SetLeft(T)
 	SetRight(T)
+	SetParent(T)
 }
 
 // Element the item that is used at the API level.
@@ -55,6 +59,55 @@ type Heap[T Element[T]] struct {
 	root T
 }
 
+// NewFromSlice builds a Heap out of es in O(n) using Floyd's bottom-up
+// heap construction: es is first linked into the same complete-binary-tree
+// shape that pushing its elements one at a time would have produced, and
+// every internal node is then sifted down, starting from the last one and
+// working back to the root. es is not modified; ownership of its elements
+// (via their Linker pointers) passes to the returned Heap.
+func NewFromSlice[T Element[T]](es []T) *Heap[T] {
+	h := &Heap[T]{size: len(es)}
+	n := len(es)
+	if n == 0 {
+		return h
+	}
+
+	// nodes tracks which Element currently occupies each level-order
+	// position; fixDown can replace a node with one of its own children,
+	// so this has to be kept up to date as we go.
+	nodes := append([]T(nil), es...)
+
+	var zero T
+	for i, e := range nodes {
+		left, right := 2*i+1, 2*i+2
+		if left < n {
+			attachLeft(e, nodes[left])
+		} else {
+			e.SetLeft(zero)
+		}
+		if right < n {
+			attachRight(e, nodes[right])
+		} else {
+			e.SetRight(zero)
+		}
+	}
+
+	for i := n/2 - 1; i >= 0; i-- {
+		nodes[i] = h.fixDown(nodes[i])
+		if i > 0 {
+			parent := (i - 1) / 2
+			if i == 2*parent+1 {
+				attachLeft(nodes[parent], nodes[i])
+			} else {
+				attachRight(nodes[parent], nodes[i])
+			}
+		}
+	}
+
+	h.setRoot(nodes[0])
+	return h
+}
+
 // Peek returns the next Element to be removed from the Heap.
 func (h *Heap[T]) Peek() T {
 	return h.root
@@ -81,7 +134,37 @@ func (h *Heap[T]) Push(e T) {
 	//     }
 	// (provided by the paper) backwards. By running it backwards, we can
 	// avoid allocating our own stack and instead use recursion.
-	h.root = h.insert(h.root, log2(h.size)-1, e)
+	h.setRoot(h.insert(h.root, log2(h.size)-1, e))
+}
+
+// setRoot installs node as the root of h, clearing its parent pointer since
+// the root has no parent.
+func (h *Heap[T]) setRoot(node T) {
+	h.root = node
+	var zero T
+	if node != zero {
+		node.SetParent(zero)
+	}
+}
+
+// attachLeft installs child as the left child of parent, keeping child's
+// Parent pointer in sync.
+func attachLeft[T Element[T]](parent, child T) {
+	parent.SetLeft(child)
+	var zero T
+	if child != zero {
+		child.SetParent(parent)
+	}
+}
+
+// attachRight installs child as the right child of parent, keeping child's
+// Parent pointer in sync.
+func attachRight[T Element[T]](parent, child T) {
+	parent.SetRight(child)
+	var zero T
+	if child != zero {
+		child.SetParent(parent)
+	}
 }
 
 func swapWithLeft[T Element[T]](cur T) T {
@@ -89,11 +172,11 @@ func swapWithLeft[T Element[T]](cur T) T {
 	newRoot := cur.Left()
 
 	orr := oldRoot.Right()
-	oldRoot.SetRight(newRoot.Right())
-	newRoot.SetRight(orr)
+	attachRight(oldRoot, newRoot.Right())
+	attachRight(newRoot, orr)
 
-	oldRoot.SetLeft(newRoot.Left())
-	newRoot.SetLeft(oldRoot)
+	attachLeft(oldRoot, newRoot.Left())
+	attachLeft(newRoot, oldRoot)
 	return newRoot
 }
 
@@ -102,11 +185,11 @@ func swapWithRight[T Element[T]](cur T) T {
 	newRoot := cur.Right()
 
 	orl := oldRoot.Left()
-	oldRoot.SetLeft(newRoot.Left())
-	newRoot.SetLeft(orl)
+	attachLeft(oldRoot, newRoot.Left())
+	attachLeft(newRoot, orl)
 
-	oldRoot.SetRight(newRoot.Right())
-	newRoot.SetRight(oldRoot)
+	attachRight(oldRoot, newRoot.Right())
+	attachRight(newRoot, oldRoot)
 	return newRoot
 }
 
@@ -127,7 +210,7 @@ func (h *Heap[T]) insert(cur T, order int, new T) T {
 	val := h.size >> uint(order)
 	if val&1 == 0 {
 		// val is even, go left.
-		cur.SetLeft(h.insert(cur.Left(), order-1, new))
+		attachLeft(cur, h.insert(cur.Left(), order-1, new))
 		if cur.Left().Less(cur) {
 			return swapWithLeft(cur)
 		}
@@ -135,7 +218,7 @@ func (h *Heap[T]) insert(cur T, order int, new T) T {
 	}
 
 	// val is odd, go right.
-	cur.SetRight(h.insert(cur.Right(), order-1, new))
+	attachRight(cur, h.insert(cur.Right(), order-1, new))
 	if cur.Right().Less(cur) {
 		return swapWithRight(cur)
 	}
@@ -154,22 +237,199 @@ func (h *Heap[T]) Pop() T {
 	removed := h.root
 
 	// See Push for why we start order at log2(h.size)-1.
-	h.root = h.remove(h.root, log2(h.size)-1)
+	last := h.remove(h.root, log2(h.size)-1)
 
 	// Decrement after so that size pointed to the position that we removed.
 	h.size--
 
 	// Fix the Heap.
 	var zero T
-	if h.root != zero {
-		h.root.SetLeft(removed.Left())
-		h.root.SetRight(removed.Right())
-		h.root = h.fixDown(h.root)
+	if last != zero {
+		attachLeft(last, removed.Left())
+		attachRight(last, removed.Right())
+		h.setRoot(h.fixDown(last))
+	} else {
+		h.setRoot(last)
 	}
 
 	return removed
 }
 
+// PopN removes and returns up to n Elements from h in ascending order. If n
+// is greater than h.Len(), PopN drains h and returns fewer than n Elements.
+// If n is negative, PopN returns no Elements.
+//
+// N.B. log2 is a single bit-scan (see log2), not a loop, so there is no
+// repeated per-call cost for PopN to amortize away; its only benefit over
+// calling Pop in a loop is the smaller, already-sized result slice.
+func (h *Heap[T]) PopN(n int) []T {
+	if n < 0 {
+		n = 0
+	}
+	if n > h.size {
+		n = h.size
+	}
+	out := make([]T, n)
+	for i := range out {
+		out[i] = h.Pop()
+	}
+	return out
+}
+
+// Meld moves every Element out of other and into h. Afterwards, other is
+// empty.
+//
+// N.B. This is a deliberate deviation from the backlog item that asked for
+// an O(log n) graft-and-sift: unlike a leftist or binomial heap, a Heap's
+// tree shape is tied exactly to its size, so there's no slot two arbitrary
+// peaps can be joined at with a single sift and still have a valid Heap of
+// the combined size. Meld instead walks whichever of h and other is
+// smaller, moving its Elements across one at a time, which costs
+// O(min(h.Len(), other.Len()) * log(h.Len()+other.Len())).
+func (h *Heap[T]) Meld(other *Heap[T]) {
+	if other == h || other == nil {
+		return
+	}
+
+	if other.size > h.size {
+		h.root, other.root = other.root, h.root
+		h.size, other.size = other.size, h.size
+	}
+
+	var zero T
+	for e := other.Pop(); e != zero; e = other.Pop() {
+		h.Push(e)
+	}
+}
+
+// Fix re-establishes the Heap invariant for e after its priority (as
+// determined by e.Less) has changed in place. e must currently be in h.
+//
+// Fix is cheaper than Remove followed by Push because it only touches the
+// path between e and its new position.
+func (h *Heap[T]) Fix(e T) {
+	if h.siftUp(e) {
+		return
+	}
+	h.siftDown(e)
+}
+
+// Update mutates e (which must currently be in h) via mutate and then
+// restores the Heap invariant. It is a convenience wrapper around Fix for
+// callers that would otherwise have to remember to call Fix themselves.
+func (h *Heap[T]) Update(e T, mutate func()) {
+	mutate()
+	h.Fix(e)
+}
+
+// Remove removes e from h and returns it. e must currently be in h.
+func (h *Heap[T]) Remove(e T) T {
+	var zero T
+	if e == zero {
+		return zero
+	}
+	if e == h.root {
+		return h.Pop()
+	}
+
+	// See Push for why we start order at log2(h.size)-1.
+	last := h.remove(h.root, log2(h.size)-1)
+	h.size--
+
+	if last == e {
+		// e was already the last element in level-order; h.remove has
+		// unlinked it from its parent, so there is nothing left to do.
+		return e
+	}
+
+	// Move last into e's old position...
+	p := e.Parent()
+	attachLeft(last, e.Left())
+	attachRight(last, e.Right())
+	if p.Left() == e {
+		attachLeft(p, last)
+	} else {
+		attachRight(p, last)
+	}
+
+	// ...and let it sift to wherever it belongs now.
+	h.Fix(last)
+
+	e.SetLeft(zero)
+	e.SetRight(zero)
+	e.SetParent(zero)
+	return e
+}
+
+// siftUp moves e towards the root for as long as it is Less than its parent,
+// reporting whether e moved.
+func (h *Heap[T]) siftUp(e T) bool {
+	moved := false
+	for {
+		var zero T
+		p := e.Parent()
+		if p == zero || !e.Less(p) {
+			return moved
+		}
+		moved = true
+
+		gp := p.Parent()
+		var newTop T
+		if p.Left() == e {
+			newTop = swapWithLeft(p)
+		} else {
+			newTop = swapWithRight(p)
+		}
+
+		if gp == zero {
+			h.setRoot(newTop)
+		} else if gp.Left() == p {
+			attachLeft(gp, newTop)
+		} else {
+			attachRight(gp, newTop)
+		}
+	}
+}
+
+// siftDown moves e away from the root for as long as its smallest child is
+// Less than it, reporting whether e moved.
+func (h *Heap[T]) siftDown(e T) bool {
+	moved := false
+	for {
+		var zero T
+		l, r := e.Left(), e.Right()
+		if l == zero && r == zero {
+			return moved
+		}
+
+		left := r == zero || l.Less(r)
+		child := r
+		if left {
+			child = l
+		}
+		if !child.Less(e) {
+			return moved
+		}
+		moved = true
+
+		p := e.Parent()
+		var newTop T
+		if left {
+			newTop = swapWithLeft(e)
+		} else {
+			newTop = swapWithRight(e)
+		}
+
+		if p == zero {
+			h.setRoot(newTop)
+		} else if p.Left() == e {
+			attachLeft(p, newTop)
+		} else {
+			attachRight(p, newTop)
+		}
+	}
+}
+
 // remove removes the last element from the heap and returns it.
 //
 // remove assumes that the Heap's size is the size before the removal.
@@ -193,6 +453,7 @@ func (h *Heap[T]) remove(cur T, order int) T {
 			got = cur.Left()
 			cur.SetLeft(zero)
 		}
+		got.SetParent(zero)
 		return got
 	}
 
@@ -204,6 +465,7 @@ func (h *Heap[T]) remove(cur T, order int) T {
 		got = cur.Right()
 		cur.SetRight(zero)
 	}
+	got.SetParent(zero)
 	return got
 }
 
@@ -223,7 +485,7 @@ func (h *Heap[T]) fixDown(cur T) T {
 			return cur
 		}
 		newRoot := swapWithLeft(cur)
-		newRoot.SetLeft(h.fixDown(newRoot.Left()))
+		attachLeft(newRoot, h.fixDown(newRoot.Left()))
 		return newRoot
 	}
 
@@ -233,7 +495,7 @@ func (h *Heap[T]) fixDown(cur T) T {
 		return cur
 	}
 	newRoot := swapWithRight(cur)
-	newRoot.SetRight(h.fixDown(newRoot.Right()))
+	attachRight(newRoot, h.fixDown(newRoot.Right()))
 	return newRoot
 }
 
@@ -277,12 +539,129 @@ func (h *Heap[T]) String() string {
 	return out
 }
 
+// Range calls f for each Element currently in h, in level order (the same
+// order in which MarshalTo writes them), until f returns false or every
+// Element has been visited.
+func (h *Heap[T]) Range(f func(T) bool) {
+	var zero T
+	if h.root == zero {
+		return
+	}
+
+	queue := []T{h.root}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		if !f(cur) {
+			return
+		}
+		if l := cur.Left(); l != zero {
+			queue = append(queue, l)
+		}
+		if r := cur.Right(); r != zero {
+			queue = append(queue, r)
+		}
+	}
+}
+
+// Clone returns a deep copy of h. copyFn is called once per Element and
+// must return a fresh T that isn't linked into any Heap; Clone takes care of
+// wiring up the copy's Left, Right and Parent itself.
+func (h *Heap[T]) Clone(copyFn func(T) T) *Heap[T] {
+	clone := &Heap[T]{size: h.size}
+
+	var link func(T) T
+	link = func(cur T) T {
+		var zero T
+		if cur == zero {
+			return zero
+		}
+		c := copyFn(cur)
+		attachLeft(c, link(cur.Left()))
+		attachRight(c, link(cur.Right()))
+		return c
+	}
+	clone.setRoot(link(h.root))
+
+	return clone
+}
+
+// MarshalTo writes h to w: first the number of Elements, then each Element
+// in level order, encoded by encode and prefixed with its length. Pair it
+// with UnmarshalFrom to checkpoint a Heap without callers having to walk
+// Left()/Right() themselves.
+func (h *Heap[T]) MarshalTo(w io.Writer, encode func(T) ([]byte, error)) error {
+	if err := binary.Write(w, binary.BigEndian, uint64(h.size)); err != nil {
+		return err
+	}
+
+	var err error
+	h.Range(func(e T) bool {
+		var b []byte
+		if b, err = encode(e); err != nil {
+			return false
+		}
+		if err = binary.Write(w, binary.BigEndian, uint64(len(b))); err != nil {
+			return false
+		}
+		if _, err = w.Write(b); err != nil {
+			return false
+		}
+		return true
+	})
+	return err
+}
+
+// UnmarshalFrom reads a Heap written by MarshalTo. Because MarshalTo writes
+// Elements in level order, the Heap invariant already holds once they're
+// relinked into the same shape, so UnmarshalFrom reconstructs it with
+// NewFromSlice's O(n) bottom-up heapify rather than pushing each Element one
+// at a time.
+func UnmarshalFrom[T Element[T]](r io.Reader, decode func([]byte) (T, error)) (*Heap[T], error) {
+	var n uint64
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return nil, err
+	}
+
+	// es is grown one Element at a time with append, and each payload is
+	// read with io.ReadAll over a io.LimitReader, rather than
+	// pre-allocating n elements or l bytes up front: a corrupt or hostile
+	// n/l then only costs as much memory as the input actually contains
+	// before a short read turns into an error, instead of an immediate
+	// multi-gigabyte allocation attempt.
+	var es []T
+	for i := uint64(0); i < n; i++ {
+		var l uint64
+		if err := binary.Read(r, binary.BigEndian, &l); err != nil {
+			return nil, err
+		}
+
+		b, err := io.ReadAll(io.LimitReader(r, int64(l)))
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(b)) != l {
+			return nil, fmt.Errorf("peap: element %d: got %d bytes, want %d", i, len(b), l)
+		}
+
+		e, err := decode(b)
+		if err != nil {
+			return nil, err
+		}
+		es = append(es, e)
+	}
+
+	return NewFromSlice(es), nil
+}
+
 // Entry is a default implementation of Linker. Users can embed this type in
 // their structs to make them automatically implement most of the methods
 // needed by Heap.
 type Entry[T Element[T]] struct {
-	left  T
-	right T
+	left   T
+	right  T
+	parent T
 }
 
 // Left returns left child of e.
@@ -295,6 +674,11 @@ func (e *Entry[T]) Right() T {
 	return e.right
 }
 
+// Parent returns the parent of e, or the zero value if e is the root.
+func (e *Entry[T]) Parent() T {
+	return e.parent
+}
+
 // SetLeft assigns elem as the left child of e.
 func (e *Entry[T]) SetLeft(elem T) {
 	e.left = elem
@@ -305,6 +689,11 @@ func (e *Entry[T]) SetRight(elem T) {
 	e.right = elem
 }
 
+// SetParent assigns elem as the parent of e.
+func (e *Entry[T]) SetParent(elem T) {
+	e.parent = elem
+}
+
 // log2 calculates the integer log base 2 of n for positive n.
 func log2(n int) int {
 	if n <= 0 {
@@ -15,10 +15,13 @@
 package peap
 
 import (
+	"bytes"
 	"container/heap"
+	"encoding/binary"
 	"fmt"
 	"math"
 	"math/rand"
+	"runtime"
 	"sort"
 	"testing"
 	"time"
@@ -105,6 +108,321 @@ func TestRemove(t *testing.T) {
 	}
 }
 
+func TestFix(t *testing.T) {
+	var h Heap[*element]
+
+	elems := make([]*element, 100)
+	for i := range elems {
+		elems[i] = &element{value: rand.Uint32() % 1000}
+		h.Push(elems[i])
+	}
+
+	// Mutate a handful of elements in place and tell the Heap to
+	// re-heapify them, then check that Pop still comes out sorted.
+	for _, i := range []int{3, 17, 42, 99} {
+		elems[i].value = rand.Uint32() % 1000
+		h.Fix(elems[i])
+	}
+
+	var prev uint32
+	for h.Len() > 0 {
+		got := h.Pop().value
+		if got < prev {
+			t.Errorf("got out-of-order value %d after %d", got, prev)
+		}
+		prev = got
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	var h Heap[*element]
+
+	for i := 0; i < 10; i++ {
+		h.Push(&element{value: uint32(i)})
+	}
+
+	// Bump the current minimum out of the way and check that the next
+	// smallest element takes its place.
+	min := h.Peek()
+	h.Update(min, func() {
+		min.value = 100
+	})
+
+	if got, want := h.Peek().value, uint32(1); got != want {
+		t.Errorf("got h.Peek().value = %d, want = %d", got, want)
+	}
+
+	if got := h.Pop(); got == min {
+		t.Errorf("got h.Pop() = %v, did not expect the updated element", got)
+	}
+}
+
+func TestRemoveElement(t *testing.T) {
+	values := make(sort.IntSlice, 0, 100)
+	elems := make([]*element, 0, 100)
+
+	var h Heap[*element]
+	for i := 0; i < 100; i++ {
+		cur := rand.Uint32() % 1000
+		values = append(values, int(cur))
+		e := &element{value: cur}
+		elems = append(elems, e)
+		h.Push(e)
+	}
+
+	// Remove every third element directly, by value, before draining the
+	// rest with Pop.
+	var removed sort.IntSlice
+	var kept sort.IntSlice
+	for i, e := range elems {
+		if i%3 == 0 {
+			if got := h.Remove(e); got != e {
+				t.Errorf("got h.Remove(elems[%d]) = %v, want = %v", i, got, e)
+			}
+			removed = append(removed, int(e.value))
+		} else {
+			kept = append(kept, int(e.value))
+		}
+	}
+	kept.Sort()
+
+	for len(kept) > 0 {
+		got := int(h.Pop().value)
+		if got != kept[0] {
+			t.Errorf("got h.Pop() = %d, want = %d", got, kept[0])
+		}
+		kept = kept[1:]
+	}
+
+	if got := h.Len(); got != 0 {
+		t.Errorf("removed all elements, got h.Len() = %d, want = 0", got)
+	}
+}
+
+func TestNewFromSlice(t *testing.T) {
+	values := make(sort.IntSlice, 0, 100)
+	es := make([]*element, 0, 100)
+	for i := 0; i < 100; i++ {
+		cur := rand.Uint32() % 1000
+		values = append(values, int(cur))
+		es = append(es, &element{value: cur})
+	}
+	values.Sort()
+
+	h := NewFromSlice(es)
+
+	if got := h.Len(); got != 100 {
+		t.Errorf("got h.Len() = %d, want = 100", got)
+	}
+	for len(values) > 0 {
+		got := int(h.Pop().value)
+		if got != values[0] {
+			t.Errorf("got h.Pop() = %d, want = %d", got, values[0])
+		}
+		values = values[1:]
+	}
+}
+
+func TestPopN(t *testing.T) {
+	var h Heap[*element]
+	for i := 10; i > 0; i-- {
+		h.Push(&element{value: uint32(i)})
+	}
+
+	got := h.PopN(4)
+	want := []uint32{1, 2, 3, 4}
+	for i, e := range got {
+		if e.value != want[i] {
+			t.Errorf("got h.PopN(4)[%d].value = %d, want = %d", i, e.value, want[i])
+		}
+	}
+
+	// Asking for more than remains should only return what's left.
+	got = h.PopN(100)
+	if len(got) != 6 {
+		t.Errorf("got len(h.PopN(100)) = %d, want = 6", len(got))
+	}
+	if h.Len() != 0 {
+		t.Errorf("got h.Len() = %d, want = 0", h.Len())
+	}
+}
+
+func TestPopNNegative(t *testing.T) {
+	var h Heap[*element]
+	h.Push(&element{value: 1})
+
+	got := h.PopN(-1)
+	if len(got) != 0 {
+		t.Errorf("got len(h.PopN(-1)) = %d, want = 0", len(got))
+	}
+	if h.Len() != 1 {
+		t.Errorf("got h.Len() = %d, want = 1", h.Len())
+	}
+}
+
+func TestMeld(t *testing.T) {
+	var a, b Heap[*element]
+
+	var values sort.IntSlice
+	for i := 0; i < 50; i++ {
+		cur := rand.Uint32() % 1000
+		values = append(values, int(cur))
+		a.Push(&element{value: cur})
+	}
+	for i := 0; i < 30; i++ {
+		cur := rand.Uint32() % 1000
+		values = append(values, int(cur))
+		b.Push(&element{value: cur})
+	}
+	values.Sort()
+
+	a.Meld(&b)
+
+	if got := a.Len(); got != 80 {
+		t.Errorf("got a.Len() = %d, want = 80", got)
+	}
+	if got := b.Len(); got != 0 {
+		t.Errorf("got b.Len() = %d, want = 0", got)
+	}
+
+	for len(values) > 0 {
+		got := int(a.Pop().value)
+		if got != values[0] {
+			t.Errorf("got a.Pop() = %d, want = %d", got, values[0])
+		}
+		values = values[1:]
+	}
+}
+
+func TestRange(t *testing.T) {
+	var h Heap[*element]
+	for i := 0; i < 20; i++ {
+		h.Push(&element{value: rand.Uint32() % 1000})
+	}
+
+	var visited int
+	h.Range(func(e *element) bool {
+		visited++
+		return true
+	})
+	if visited != h.Len() {
+		t.Errorf("got %d visited, want = %d", visited, h.Len())
+	}
+
+	visited = 0
+	h.Range(func(e *element) bool {
+		visited++
+		return visited < 5
+	})
+	if visited != 5 {
+		t.Errorf("got %d visited after early return, want = 5", visited)
+	}
+}
+
+func TestClone(t *testing.T) {
+	var h Heap[*element]
+	for i := 0; i < 50; i++ {
+		h.Push(&element{value: rand.Uint32() % 1000})
+	}
+
+	clone := h.Clone(func(e *element) *element {
+		return &element{value: e.value}
+	})
+
+	if got := clone.Len(); got != h.Len() {
+		t.Errorf("got clone.Len() = %d, want = %d", got, h.Len())
+	}
+
+	for h.Len() > 0 {
+		want := h.Pop().value
+		got := clone.Pop().value
+		if got != want {
+			t.Errorf("got clone.Pop().value = %d, want = %d", got, want)
+		}
+	}
+}
+
+func TestMarshalUnmarshal(t *testing.T) {
+	var h Heap[*element]
+	var values sort.IntSlice
+	for i := 0; i < 50; i++ {
+		v := rand.Uint32() % 1000
+		values = append(values, int(v))
+		h.Push(&element{value: v})
+	}
+	values.Sort()
+
+	var buf bytes.Buffer
+	encode := func(e *element) ([]byte, error) {
+		b := make([]byte, 4)
+		binary.BigEndian.PutUint32(b, e.value)
+		return b, nil
+	}
+	if err := h.MarshalTo(&buf, encode); err != nil {
+		t.Fatalf("MarshalTo() = %v", err)
+	}
+
+	decode := func(b []byte) (*element, error) {
+		return &element{value: binary.BigEndian.Uint32(b)}, nil
+	}
+	got, err := UnmarshalFrom(&buf, decode)
+	if err != nil {
+		t.Fatalf("UnmarshalFrom() = %v", err)
+	}
+
+	if got.Len() != 50 {
+		t.Errorf("got Len() = %d, want = 50", got.Len())
+	}
+	for len(values) > 0 {
+		v := int(got.Pop().value)
+		if v != values[0] {
+			t.Errorf("got Pop() = %d, want = %d", v, values[0])
+		}
+		values = values[1:]
+	}
+}
+
+func TestUnmarshalFromRejectsCorruptHeader(t *testing.T) {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, uint64(1)<<40) // claims 2^40 Elements
+
+	decode := func(b []byte) (*element, error) {
+		return &element{value: binary.BigEndian.Uint32(b)}, nil
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	if _, err := UnmarshalFrom(&buf, decode); err == nil {
+		t.Error("got nil error for a header claiming 2^40 elements with no payload, want an error")
+	}
+	runtime.ReadMemStats(&after)
+
+	if grew := after.TotalAlloc - before.TotalAlloc; grew > 1<<20 {
+		t.Errorf("got %d bytes allocated rejecting a corrupt header, want < 1MiB", grew)
+	}
+}
+
+func TestUnmarshalFromRejectsCorruptLength(t *testing.T) {
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, uint64(1))     // one Element...
+	binary.Write(&buf, binary.BigEndian, uint64(1)<<40) // ...claiming a 2^40 byte payload
+
+	decode := func(b []byte) (*element, error) {
+		return &element{value: binary.BigEndian.Uint32(b)}, nil
+	}
+
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	if _, err := UnmarshalFrom(&buf, decode); err == nil {
+		t.Error("got nil error for an element claiming a 2^40 byte payload with no payload, want an error")
+	}
+	runtime.ReadMemStats(&after)
+
+	if grew := after.TotalAlloc - before.TotalAlloc; grew > 1<<20 {
+		t.Errorf("got %d bytes allocated rejecting a corrupt length, want < 1MiB", grew)
+	}
+}
+
 func TestString(t *testing.T) {
 	var h Heap[*element]
 